@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BNIParser is a stub StatementParser for Bank BNI statements. Detect
+// recognizes BNI's statement header so Registry can route to it instead of
+// misdetecting the file as BCA; Parse is not implemented yet.
+type BNIParser struct {
+	Filename     string
+	AccountInfo  AccountInfo
+	Transactions []Transaction
+	Summary      Summary
+}
+
+// NewBNIParser creates a new BNI parser instance.
+func NewBNIParser(filename string) *BNIParser {
+	return &BNIParser{Filename: filename}
+}
+
+// Detect implements StatementParser.
+func (p *BNIParser) Detect(lines []string) bool {
+	for _, line := range lines {
+		if strings.Contains(strings.ToUpper(line), "BANK NEGARA INDONESIA") || strings.Contains(strings.ToUpper(line), "BNI") {
+			return true
+		}
+	}
+	return false
+}
+
+// Parse implements StatementParser. Not yet implemented.
+func (p *BNIParser) Parse() error {
+	return fmt.Errorf("BNIParser: parsing Bank BNI statements is not yet implemented")
+}
+
+// Result implements StatementParser.
+func (p *BNIParser) Result() (AccountInfo, []Transaction, Summary) {
+	return p.AccountInfo, p.Transactions, p.Summary
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+)
+
+// uncategorizedLabel is the Category assigned when no rule matches.
+const uncategorizedLabel = "Uncategorized"
+
+// CategoryRule matches a transaction description against Pattern — a
+// literal substring by default, or a regular expression when Regex is true
+// — and assigns Category on a match. Rules are tried in order; the first
+// match wins. A rule set is typically loaded from YAML or JSON and passed
+// to NewCategorizer.
+type CategoryRule struct {
+	Pattern  string `json:"pattern" yaml:"pattern"`
+	Regex    bool   `json:"regex" yaml:"regex"`
+	Category string `json:"category" yaml:"category"`
+}
+
+// Categorizer applies an ordered list of CategoryRules to transaction
+// descriptions, assigning Transaction.Category during Parse.
+type Categorizer struct {
+	Rules []CategoryRule
+
+	compiled []*regexp.Regexp // parallel to Rules; nil for non-regex rules
+}
+
+// NewCategorizer builds a Categorizer from rules, pre-compiling any regex
+// patterns so Categorize doesn't recompile them per transaction. A rule
+// with Regex true whose Pattern fails to compile is silently treated as
+// never matching, rather than rejecting the whole rule set.
+func NewCategorizer(rules []CategoryRule) *Categorizer {
+	c := &Categorizer{Rules: rules, compiled: make([]*regexp.Regexp, len(rules))}
+	for i, rule := range rules {
+		if rule.Regex {
+			if re, err := regexp.Compile(rule.Pattern); err == nil {
+				c.compiled[i] = re
+			}
+		}
+	}
+	return c
+}
+
+// DefaultCategorizer returns a Categorizer pre-loaded with DefaultCategoryRules,
+// so users get categorized output without writing a rule file first.
+func DefaultCategorizer() *Categorizer {
+	return NewCategorizer(DefaultCategoryRules)
+}
+
+// DefaultCategoryRules covers the BCA description strings seen most often in
+// statements: interbank transfers, e-wallet top-ups, and card payments.
+// Descriptions that match none of these fall back to "Uncategorized".
+var DefaultCategoryRules = []CategoryRule{
+	{Pattern: "QRIS", Category: "QRIS Payment"},
+	{Pattern: "GOPAY", Category: "E-Wallet Top-up"},
+	{Pattern: "OVO", Category: "E-Wallet Top-up"},
+	{Pattern: "DANA", Category: "E-Wallet Top-up"},
+	{Pattern: "SHOPEEPAY", Category: "E-Wallet Top-up"},
+	{Pattern: "BI-FAST", Category: "Bank Transfer"},
+	{Pattern: "TRSF E-BANKING", Category: "Bank Transfer"},
+	{Pattern: "TRANSFER", Category: "Bank Transfer"},
+	{Pattern: "KARTU DEBIT", Category: "Card Payment"},
+	{Pattern: "TARIKAN ATM", Category: "ATM Withdrawal"},
+	{Pattern: "BIAYA ADM", Category: "Bank Fee"},
+	{Pattern: "BUNGA", Category: "Interest"},
+	{Pattern: "PAJAK", Category: "Tax"},
+}
+
+// CategoryTotal holds the aggregated credit/debit/net totals for one
+// Transaction.Category, used to feed the Category Summary sheet and chart.
+type CategoryTotal struct {
+	Category string
+	Credits  float64
+	Debits   float64
+	Net      float64
+	Count    int
+}
+
+// categoryTotals groups p.Transactions by Category and sums credits, debits,
+// net (credits minus debits), and transaction count per category, in the
+// order each category first appears.
+func (p *BCAParser) categoryTotals() []CategoryTotal {
+	order := make([]string, 0)
+	byCategory := make(map[string]*CategoryTotal)
+
+	for _, txn := range p.Transactions {
+		if txn.Type != "CR" && txn.Type != "DB" {
+			continue // skip OPENING and any other row without a CR/DB type
+		}
+		category := txn.Category
+		if category == "" {
+			category = uncategorizedLabel
+		}
+		ct, ok := byCategory[category]
+		if !ok {
+			ct = &CategoryTotal{Category: category}
+			byCategory[category] = ct
+			order = append(order, category)
+		}
+		ct.Count++
+		switch txn.Type {
+		case "CR":
+			ct.Credits += txn.Amount
+		case "DB":
+			ct.Debits += txn.Amount
+		}
+		ct.Net = ct.Credits - ct.Debits
+	}
+
+	totals := make([]CategoryTotal, len(order))
+	for i, category := range order {
+		totals[i] = *byCategory[category]
+	}
+	return totals
+}
+
+// Categorize returns the Category for description, or "Uncategorized" if no
+// rule matches. A nil Categorizer also returns "Uncategorized", so callers
+// don't need a nil check before use.
+func (c *Categorizer) Categorize(description string) string {
+	if c == nil {
+		return uncategorizedLabel
+	}
+	upper := strings.ToUpper(description)
+	for i, rule := range c.Rules {
+		if rule.Regex {
+			if re := c.compiled[i]; re != nil && re.MatchString(description) {
+				return rule.Category
+			}
+			continue
+		}
+		if strings.Contains(upper, strings.ToUpper(rule.Pattern)) {
+			return rule.Category
+		}
+	}
+	return uncategorizedLabel
+}
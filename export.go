@@ -0,0 +1,221 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// statementDocument is the shape written by ExportToJSON: the same three
+// sections the XLSX and CSV writers produce, collected into one document.
+type statementDocument struct {
+	AccountInfo  AccountInfo   `json:"account_info"`
+	Transactions []Transaction `json:"transactions"`
+	Summary      Summary       `json:"summary"`
+}
+
+// Export writes the parsed statement to filename using the writer that
+// matches format ("xlsx", "csv", "json", or "ndjson"). If format is empty,
+// it is inferred from filename's extension. This lets ETL pipelines depend
+// on a single entry point instead of picking a method per output type.
+func (p *BCAParser) Export(filename, format string) error {
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(filename)), ".")
+	}
+
+	switch format {
+	case "xlsx":
+		return p.ExportToExcel(filename)
+	case "csv":
+		return p.ExportToCSV(filename)
+	case "json":
+		return p.ExportToJSON(filename)
+	case "ndjson":
+		return p.exportToNDJSON(filename)
+	default:
+		return fmt.Errorf("unsupported export format: %q", format)
+	}
+}
+
+// ExportToCSV writes the Account Info, Transactions, and Summary sections to
+// three sibling CSV files derived from filename, e.g. "statement.csv" becomes
+// "statement_account.csv", "statement_transactions.csv", and
+// "statement_summary.csv". Use ExportToCSVCombined to get a single file.
+func (p *BCAParser) ExportToCSV(filename string) error {
+	ext := filepath.Ext(filename)
+	base := strings.TrimSuffix(filename, ext)
+	if ext == "" {
+		ext = ".csv"
+	}
+
+	if err := p.writeAccountInfoCSV(base + "_account" + ext); err != nil {
+		return err
+	}
+	if err := p.writeTransactionsCSV(base + "_transactions" + ext); err != nil {
+		return err
+	}
+	if err := p.writeSummaryCSV(base + "_summary" + ext); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ExportToCSVCombined writes Account Info, Transactions, and Summary into a
+// single CSV file, one section per block separated by a blank line.
+func (p *BCAParser) ExportToCSVCombined(filename string) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	defer w.Flush()
+
+	if err := p.writeAccountInfoRows(w); err != nil {
+		return err
+	}
+	w.Write([]string{})
+	if err := p.writeTransactionsRows(w); err != nil {
+		return err
+	}
+	w.Write([]string{})
+	if err := p.writeSummaryRows(w); err != nil {
+		return err
+	}
+	return w.Error()
+}
+
+func (p *BCAParser) writeAccountInfoCSV(filename string) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	defer w.Flush()
+	if err := p.writeAccountInfoRows(w); err != nil {
+		return err
+	}
+	return w.Error()
+}
+
+func (p *BCAParser) writeAccountInfoRows(w *csv.Writer) error {
+	rows := [][]string{
+		{"Account Number", "Period", "Account Holder", "Currency"},
+		{p.AccountInfo.AccountNumber, p.AccountInfo.Period, p.AccountInfo.AccountHolder, p.AccountInfo.Currency},
+	}
+	return w.WriteAll(rows)
+}
+
+func (p *BCAParser) writeTransactionsCSV(filename string) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	defer w.Flush()
+	if err := p.writeTransactionsRows(w); err != nil {
+		return err
+	}
+	return w.Error()
+}
+
+func (p *BCAParser) writeTransactionsRows(w *csv.Writer) error {
+	if err := w.Write([]string{"Date", "Description", "Type", "Amount", "Balance"}); err != nil {
+		return err
+	}
+	for _, txn := range p.Transactions {
+		row := []string{
+			txn.Date.Format("2006-01-02"),
+			txn.Description,
+			txn.Type,
+			formatCSVAmount(txn.Amount),
+			formatCSVAmount(txn.Balance),
+		}
+		if err := w.Write(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *BCAParser) writeSummaryCSV(filename string) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	defer w.Flush()
+	if err := p.writeSummaryRows(w); err != nil {
+		return err
+	}
+	return w.Error()
+}
+
+func (p *BCAParser) writeSummaryRows(w *csv.Writer) error {
+	rows := [][]string{
+		{"Opening Balance", "Total Credits", "Credit Count", "Total Debits", "Debit Count", "Closing Balance"},
+		{
+			formatCSVAmount(p.Summary.OpeningBalance),
+			formatCSVAmount(p.Summary.TotalCredits),
+			strconv.Itoa(p.Summary.CreditCount),
+			formatCSVAmount(p.Summary.TotalDebits),
+			strconv.Itoa(p.Summary.DebitCount),
+			formatCSVAmount(p.Summary.ClosingBalance),
+		},
+	}
+	return w.WriteAll(rows)
+}
+
+// formatCSVAmount renders an amount with two decimal places and no thousand
+// separators, blank when zero so empty cells (e.g. the opening balance row's
+// Amount) match the XLSX writer's behavior of leaving them unset.
+func formatCSVAmount(amount float64) string {
+	if amount == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(amount, 'f', 2, 64)
+}
+
+// ExportToJSON writes Account Info, Transactions, and Summary as a single
+// JSON document with "account_info", "transactions", and "summary" keys.
+func (p *BCAParser) ExportToJSON(filename string) error {
+	doc := statementDocument{
+		AccountInfo:  p.AccountInfo,
+		Transactions: p.Transactions,
+		Summary:      p.Summary,
+	}
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, data, 0644)
+}
+
+// exportToNDJSON writes one JSON object per transaction, newline-delimited,
+// for ETL pipelines that stream rows instead of loading a whole document.
+func (p *BCAParser) exportToNDJSON(filename string) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	enc := json.NewEncoder(f)
+	for _, txn := range p.Transactions {
+		if err := enc.Encode(txn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
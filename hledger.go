@@ -0,0 +1,141 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// HledgerRule picks the counter-account posted against a transaction in the
+// generated hledger journal. Modeled on hledger's CSV rules / rewrite
+// plugin: Match is a regex tested against Transaction.Description, and
+// MatchType optionally restricts the rule to one Transaction.Type ("CR" or
+// "DB"). Rules are tried in order; the first match wins.
+type HledgerRule struct {
+	Match     string
+	MatchType string
+	Account   string
+
+	compiled *regexp.Regexp
+}
+
+// DefaultHledgerRules covers common BCA description strings so a default
+// HledgerWriter produces a usable journal before the caller supplies
+// account-specific rules.
+var DefaultHledgerRules = []HledgerRule{
+	{Match: "(?i)QRIS", Account: "expenses:qris"},
+	{Match: "(?i)GOPAY|OVO|DANA|SHOPEEPAY", Account: "assets:ewallet"},
+	{Match: "(?i)BIAYA ADM", Account: "expenses:bank-fees"},
+	{Match: "(?i)BUNGA", MatchType: "CR", Account: "income:interest"},
+	{Match: "(?i)TARIKAN ATM", Account: "assets:cash"},
+}
+
+// HledgerWriter renders parsed transactions as an hledger-compatible plain
+// text journal. Each transaction becomes a dated entry with two balanced
+// postings: one against AssetAccount, one against a counter-account chosen
+// by Rules (falling back to expenses:unknown / income:unknown by Type).
+type HledgerWriter struct {
+	// AssetAccount is the BCA-side posting. Defaults to
+	// "assets:bca:<AccountNumber>" when empty.
+	AssetAccount string
+	Rules        []HledgerRule
+}
+
+// NewHledgerWriter builds an HledgerWriter with rules pre-compiled, using
+// DefaultHledgerRules when rules is nil. A rule whose Match fails to
+// compile is silently skipped rather than rejecting the whole set.
+func NewHledgerWriter(rules []HledgerRule) *HledgerWriter {
+	if rules == nil {
+		rules = DefaultHledgerRules
+	}
+	w := &HledgerWriter{Rules: make([]HledgerRule, len(rules))}
+	copy(w.Rules, rules)
+	for i := range w.Rules {
+		if re, err := regexp.Compile(w.Rules[i].Match); err == nil {
+			w.Rules[i].compiled = re
+		}
+	}
+	return w
+}
+
+// counterAccount returns the account txn should be posted against, per
+// w.Rules, falling back to expenses:unknown / income:unknown by Type.
+func (w *HledgerWriter) counterAccount(txn Transaction) string {
+	for _, rule := range w.Rules {
+		if rule.compiled == nil {
+			continue
+		}
+		if rule.MatchType != "" && rule.MatchType != txn.Type {
+			continue
+		}
+		if rule.compiled.MatchString(txn.Description) {
+			return rule.Account
+		}
+	}
+	if txn.Type == "CR" {
+		return "income:unknown"
+	}
+	return "expenses:unknown"
+}
+
+// ExportToHledger writes p's transactions as an hledger journal using w (or
+// a default HledgerWriter if w is nil). The SALDO AWAL row becomes an
+// opening-balance entry against equity:opening-balances; every other row
+// becomes a dated entry balanced between AssetAccount and the counter-
+// account w.counterAccount picks.
+func (p *BCAParser) ExportToHledger(filename string, w *HledgerWriter) error {
+	if w == nil {
+		w = NewHledgerWriter(nil)
+	}
+
+	asset := w.AssetAccount
+	if asset == "" {
+		asset = fmt.Sprintf("assets:bca:%s", p.AccountInfo.AccountNumber)
+	}
+	currency := p.AccountInfo.Currency
+	if currency == "" {
+		currency = "IDR"
+	}
+
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var sb strings.Builder
+	for _, txn := range p.Transactions {
+		if txn.Type == "OPENING" {
+			fmt.Fprintf(&sb, "%s SALDO AWAL\n", txn.Date.Format("2006-01-02"))
+			fmt.Fprintf(&sb, "    %-30s %s %s\n", asset, formatHledgerAmount(txn.Balance), currency)
+			fmt.Fprintf(&sb, "    %s\n\n", "equity:opening-balances")
+			continue
+		}
+
+		desc := txn.Description
+		if desc == "" {
+			desc = txn.Type
+		}
+
+		assetAmount := txn.Amount
+		if txn.Type == "DB" {
+			assetAmount = -assetAmount
+		}
+		counterAmount := -assetAmount
+
+		fmt.Fprintf(&sb, "%s %s\n", txn.Date.Format("2006-01-02"), desc)
+		fmt.Fprintf(&sb, "    %-30s %s %s\n", asset, formatHledgerAmount(assetAmount), currency)
+		fmt.Fprintf(&sb, "    %-30s %s %s\n\n", w.counterAccount(txn), formatHledgerAmount(counterAmount), currency)
+	}
+
+	_, err = f.WriteString(sb.String())
+	return err
+}
+
+// formatHledgerAmount renders an amount with two decimal places; negative
+// amounts carry a leading minus, positive ones are unsigned.
+func formatHledgerAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', 2, 64)
+}
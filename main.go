@@ -0,0 +1,168 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ANSI color codes used by PrintSummary.
+const (
+	ColorBlue  = "\033[34m"
+	ColorGreen = "\033[32m"
+	ColorRed   = "\033[31m"
+	ColorReset = "\033[0m"
+)
+
+func main() {
+	if len(os.Args) > 1 && os.Args[1] == "merge" {
+		runMerge(os.Args[2:])
+		return
+	}
+	runConvert(os.Args[1:])
+}
+
+// runConvert is the default single-statement path: parse one file and
+// export it in the requested format.
+func runConvert(args []string) {
+	fs := flag.NewFlagSet("bca-converter", flag.ExitOnError)
+	format := fs.String("format", "", "output format: xlsx, csv, json, ndjson, mt940 (default: inferred from -output's extension; mt940 is only selected by -format)")
+	output := fs.String("output", "", "output file path (default: input filename with the format's extension)")
+	strict := fs.Bool("strict", false, "exit non-zero if Verify finds any discrepancy")
+	rulesFile := fs.String("rules", "", "path to a JSON rules file applied to every transaction (see RuleSet)")
+	explain := fs.Bool("explain", false, "print which rule fired for each transaction (requires -rules)")
+	fs.Parse(args)
+
+	rest := fs.Args()
+	if len(rest) < 1 {
+		fmt.Fprintln(os.Stderr, "usage: bca-converter [-format xlsx|csv|json|ndjson|mt940] [-output FILE] <statement.txt>")
+		os.Exit(1)
+	}
+
+	input := rest[0]
+
+	// Registry picks the parser by sniffing the file rather than requiring
+	// a -bank flag; today only BCA is fully implemented, but Mandiri/BNI
+	// statements are routed to their (stub) parsers instead of silently
+	// being misparsed as BCA.
+	detected, err := NewRegistry().Detect(input)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "detect error: %v\n", err)
+		os.Exit(1)
+	}
+	if err := detected.Parse(); err != nil {
+		fmt.Fprintf(os.Stderr, "parse error: %v\n", err)
+		os.Exit(1)
+	}
+
+	parser, ok := detected.(*BCAParser)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "export error: %T is not yet supported for export\n", detected)
+		os.Exit(1)
+	}
+	parser.PrintSummary()
+
+	if *rulesFile != "" {
+		ruleSet, err := LoadRuleSet(*rulesFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "rules error: %v\n", err)
+			os.Exit(1)
+		}
+		parser.ApplyRules(ruleSet)
+		if *explain {
+			printRuleExplanations(parser, ruleSet)
+		}
+	}
+
+	if *strict {
+		if discrepancies := parser.Verify(); len(discrepancies) > 0 {
+			fmt.Fprintf(os.Stderr, "-strict: %d integrity discrepancies found, aborting\n", len(discrepancies))
+			os.Exit(1)
+		}
+	}
+
+	out := *output
+	if out == "" {
+		ext := *format
+		if ext == "" {
+			ext = "xlsx"
+		}
+		out = strings.TrimSuffix(input, filepath.Ext(input)) + "." + ext
+	}
+
+	if err := writeOutput(parser, out, *format); err != nil {
+		fmt.Fprintf(os.Stderr, "export error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// printRuleExplanations prints, for each transaction, which rule (by
+// position in ruleSet.Rules) fired for it, or "no rule matched" if none
+// did — the -explain flag's output.
+func printRuleExplanations(parser *BCAParser, ruleSet *RuleSet) {
+	for i, txn := range parser.Transactions {
+		fired := ruleSet.Explain[i]
+		if fired < 0 {
+			fmt.Printf("  [%s] %s: no rule matched\n", txn.Date.Format("2006-01-02"), txn.Description)
+			continue
+		}
+		fmt.Printf("  [%s] %s: rule #%d matched (%q)\n", txn.Date.Format("2006-01-02"), txn.Description, fired, ruleSet.Rules[fired].Match)
+	}
+}
+
+// writeOutput dispatches to parser.Export for the formats it already
+// handles, plus MT940 — which lives outside Export's extension-based
+// switch since ".mt940" isn't a format excelize/encoding readers recognize
+// by extension alone.
+func writeOutput(parser *BCAParser, out, format string) error {
+	if format == "mt940" {
+		return (&MT940Writer{}).Write(parser, out)
+	}
+	return parser.Export(out, format)
+}
+
+// runMerge implements `bca-converter merge a.pdf b.pdf c.pdf -o year.xlsx`:
+// parse every input statement, reconcile and merge them with Merger, and
+// export the result as one workbook. -o is scanned manually (rather than
+// via flag.FlagSet) so it can appear after the positional file list, as in
+// the example above.
+func runMerge(args []string) {
+	output := "merged.xlsx"
+	var inputs []string
+	for i := 0; i < len(args); i++ {
+		if args[i] == "-o" && i+1 < len(args) {
+			output = args[i+1]
+			i++
+			continue
+		}
+		inputs = append(inputs, args[i])
+	}
+
+	if len(inputs) == 0 {
+		fmt.Fprintln(os.Stderr, "usage: bca-converter merge <a.pdf> <b.pdf> ... -o <output.xlsx>")
+		os.Exit(1)
+	}
+
+	parsers := make([]*BCAParser, 0, len(inputs))
+	for _, input := range inputs {
+		p := NewBCAParser(input)
+		if err := p.Parse(); err != nil {
+			fmt.Fprintf(os.Stderr, "parse error (%s): %v\n", input, err)
+			os.Exit(1)
+		}
+		parsers = append(parsers, p)
+	}
+
+	merged, err := NewMerger().Merge(parsers...)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "merge error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := merged.ExportToExcel(output); err != nil {
+		fmt.Fprintf(os.Stderr, "export error: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MandiriParser is a stub StatementParser for Bank Mandiri statements.
+// Detect recognizes Mandiri's statement header so Registry can route to it
+// instead of misdetecting the file as BCA; Parse is not implemented yet.
+// It exists to prove the StatementParser extension point with a second,
+// genuinely different bank rather than just the one BCA implementation.
+type MandiriParser struct {
+	Filename     string
+	AccountInfo  AccountInfo
+	Transactions []Transaction
+	Summary      Summary
+}
+
+// NewMandiriParser creates a new Mandiri parser instance.
+func NewMandiriParser(filename string) *MandiriParser {
+	return &MandiriParser{Filename: filename}
+}
+
+// Detect implements StatementParser.
+func (p *MandiriParser) Detect(lines []string) bool {
+	for _, line := range lines {
+		if strings.Contains(strings.ToUpper(line), "BANK MANDIRI") {
+			return true
+		}
+	}
+	return false
+}
+
+// Parse implements StatementParser. Not yet implemented.
+func (p *MandiriParser) Parse() error {
+	return fmt.Errorf("MandiriParser: parsing Bank Mandiri statements is not yet implemented")
+}
+
+// Result implements StatementParser.
+func (p *MandiriParser) Result() (AccountInfo, []Transaction, Summary) {
+	return p.AccountInfo, p.Transactions, p.Summary
+}
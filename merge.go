@@ -0,0 +1,133 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+)
+
+// MergeParsers combines the parsed Transactions of several already-Parse'd
+// BCAParsers (e.g. one per monthly PDF) into a single parser covering their
+// union period. Rows are deduplicated on (Date, Amount, Balance,
+// Description) so that a transaction appearing in the tail of one
+// statement and the head of the next — common when monthly exports overlap
+// by a day or two — is kept only once. Only the first parser's SALDO
+// AWAL/"OPENING" row is kept; every other parser's opening row restates the
+// previous file's closing balance and would otherwise leave multiple
+// opening rows interleaved in the merged list, which makes Verify reseed
+// its running balance mid-statement and report spurious discrepancies.
+// Each surviving transaction is stamped with the filename it came from via
+// Transaction.Source.
+//
+// Merger.Merge (merger.go) wraps this with boundary reconciliation and a
+// looser, hledger-print-unique-style duplicate key of its own — see
+// mergeParsers for the shared plumbing both paths use.
+func MergeParsers(parsers ...*BCAParser) (*BCAParser, error) {
+	return mergeParsers(parsers, dedupeKey, false)
+}
+
+// mergeParsers is the merge logic shared by MergeParsers and Merger.Merge.
+// keyFn determines what counts as a duplicate; when collapseImpossible is
+// true, a second pass (dropImpossibleBalanceSequences) also drops rows
+// keyFn's exact match misses.
+func mergeParsers(parsers []*BCAParser, keyFn func(Transaction) string, collapseImpossible bool) (*BCAParser, error) {
+	if len(parsers) == 0 {
+		return nil, fmt.Errorf("MergeParsers: at least one parser is required")
+	}
+
+	merged := NewBCAParser("")
+	merged.AccountInfo = parsers[0].AccountInfo
+	merged.AccountInfo.Period = spanPeriod(parsers)
+	merged.Categorizer = parsers[0].Categorizer
+
+	seen := make(map[string]bool)
+	for fileIndex, p := range parsers {
+		source := filepath.Base(p.Filename)
+		for _, txn := range p.Transactions {
+			if txn.Type == "OPENING" && fileIndex > 0 {
+				continue
+			}
+			key := keyFn(txn)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			txn.Source = source
+			merged.Transactions = append(merged.Transactions, txn)
+		}
+	}
+
+	sort.SliceStable(merged.Transactions, func(i, j int) bool {
+		return merged.Transactions[i].Date.Before(merged.Transactions[j].Date)
+	})
+
+	if collapseImpossible {
+		merged.Transactions = dropImpossibleBalanceSequences(merged.Transactions)
+	}
+	merged.Summary = summarizeTransactions(merged.Transactions)
+
+	return merged, nil
+}
+
+// dedupeKey identifies a transaction for MergeParsers' cross-statement
+// duplicate detection. Amount and Balance are formatted to two decimals so
+// that float rounding differences between two exports of the same row
+// don't produce distinct keys.
+func dedupeKey(txn Transaction) string {
+	return fmt.Sprintf("%s|%.2f|%.2f|%s", txn.Date.Format("2006-01-02"), txn.Amount, txn.Balance, txn.Description)
+}
+
+// spanPeriod joins the first and last non-empty Period strings across
+// parsers (assumed to already be in chronological order) into a single
+// range label, e.g. "JANUARI 2026 - DESEMBER 2026".
+func spanPeriod(parsers []*BCAParser) string {
+	first, last := "", ""
+	for _, p := range parsers {
+		if p.AccountInfo.Period == "" {
+			continue
+		}
+		if first == "" {
+			first = p.AccountInfo.Period
+		}
+		last = p.AccountInfo.Period
+	}
+	if first == "" || first == last {
+		return first
+	}
+	return fmt.Sprintf("%s - %s", first, last)
+}
+
+// summarizeTransactions recomputes a Summary from a deduplicated,
+// chronologically-sorted transaction list, rather than summing the
+// per-statement Summary values (which would double-count any rows dropped
+// as duplicates).
+func summarizeTransactions(txns []Transaction) Summary {
+	var s Summary
+	for i, txn := range txns {
+		switch txn.Type {
+		case "OPENING":
+			if i == 0 {
+				s.OpeningBalance = txn.Balance
+			}
+		case "CR":
+			s.TotalCredits += txn.Amount
+			s.CreditCount++
+		case "DB":
+			s.TotalDebits += txn.Amount
+			s.DebitCount++
+		}
+		s.ClosingBalance = txn.Balance
+	}
+	return s
+}
+
+// ExportMergedToExcel merges parsers with MergeParsers and writes the
+// result to a single workbook, so users can combine twelve monthly PDFs
+// into one yearly spreadsheet in one call.
+func ExportMergedToExcel(filename string, parsers ...*BCAParser) error {
+	merged, err := MergeParsers(parsers...)
+	if err != nil {
+		return err
+	}
+	return merged.ExportToExcel(filename)
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"path/filepath"
+	"strings"
+)
+
+// Merger consolidates several parsed monthly statements into one, the way
+// MergeParsers does, but first reconciles each pair of consecutive
+// statements' balances and refuses to merge if they diverge — a break
+// there usually means a missing statement or a parser regression, not a
+// real gap, and silently merging past it would produce a spreadsheet with
+// a wrong running balance. Merger also uses a looser, hledger-print-unique
+// style duplicate key than MergeParsers' exact-field match (see
+// hledgerDedupeKey), since the OCR noise across a month boundary is the
+// main thing standing between twelve monthly PDFs and one clean ledger.
+type Merger struct {
+	// ReconcileEpsilon is the tolerance for comparing one file's
+	// ClosingBalance against the next file's OpeningBalance. Defaults to
+	// balanceEpsilon when zero.
+	ReconcileEpsilon float64
+}
+
+// NewMerger builds a Merger using the default reconciliation tolerance.
+func NewMerger() *Merger {
+	return &Merger{ReconcileEpsilon: balanceEpsilon}
+}
+
+// Merge reconciles parsers (expected to already be in chronological order,
+// e.g. one per month) and merges them into a single consolidated
+// *BCAParser, sorted by date and deduplicated on (Date, Amount, Type,
+// normalized-description hash) via hledgerDedupeKey, plus a second pass
+// (dropImpossibleBalanceSequences) dropping rows that key misses.
+func (m *Merger) Merge(parsers ...*BCAParser) (*BCAParser, error) {
+	if err := m.reconcileBoundaries(parsers); err != nil {
+		return nil, err
+	}
+	return mergeParsers(parsers, hledgerDedupeKey, true)
+}
+
+// hledgerDedupeKey identifies a transaction for Merger's cross-statement
+// duplicate detection: Date, Amount, Type, and a normalized-description
+// hash must all match, the same criteria hledger's print-unique uses.
+// Unlike MergeParsers' dedupeKey, Balance is deliberately excluded — two
+// exports of the same row can disagree on Balance after an upstream
+// parser fix, and Merge already reconciles each file's overall balance
+// boundary separately via reconcileBoundaries.
+func hledgerDedupeKey(txn Transaction) string {
+	return fmt.Sprintf("%s|%.2f|%s|%s", txn.Date.Format("2006-01-02"), txn.Amount, txn.Type, normalizedDescriptionHash(txn.Description))
+}
+
+// normalizedDescriptionHash collapses case and whitespace runs before
+// hashing, so two exports of the same row that differ only in spacing or
+// letter case (common across a month boundary's overlap region) still
+// land on the same key.
+func normalizedDescriptionHash(description string) string {
+	normalized := strings.Join(strings.Fields(strings.ToUpper(description)), " ")
+	h := fnv.New64a()
+	h.Write([]byte(normalized))
+	return fmt.Sprintf("%x", h.Sum64())
+}
+
+// dropImpossibleBalanceSequences catches the duplicates hledgerDedupeKey's
+// exact match misses: a nonzero transaction always moves the running
+// balance, so two adjacent rows (once sorted by date) from different
+// source files whose Balance is unchanged despite a nonzero Amount means
+// one of them is the other restated across the files' overlapping days.
+// The later row is dropped; txns must already be sorted by Date.
+func dropImpossibleBalanceSequences(txns []Transaction) []Transaction {
+	if len(txns) < 2 {
+		return txns
+	}
+	kept := txns[:1]
+	for i := 1; i < len(txns); i++ {
+		prev := kept[len(kept)-1]
+		curr := txns[i]
+		if curr.Source != prev.Source && curr.Amount != 0 && math.Abs(curr.Balance-prev.Balance) < balanceEpsilon {
+			continue
+		}
+		kept = append(kept, curr)
+	}
+	return kept
+}
+
+// reconcileBoundaries checks that each file's Summary.ClosingBalance
+// matches the next file's Summary.OpeningBalance, returning an error that
+// names both files on the first mismatch found.
+func (m *Merger) reconcileBoundaries(parsers []*BCAParser) error {
+	epsilon := m.ReconcileEpsilon
+	if epsilon == 0 {
+		epsilon = balanceEpsilon
+	}
+
+	for i := 0; i < len(parsers)-1; i++ {
+		current, next := parsers[i], parsers[i+1]
+		if math.Abs(current.Summary.ClosingBalance-next.Summary.OpeningBalance) > epsilon {
+			return fmt.Errorf(
+				"Merger: closing balance of %q (%.2f) does not match opening balance of %q (%.2f) — refusing to merge",
+				filepath.Base(current.Filename), current.Summary.ClosingBalance,
+				filepath.Base(next.Filename), next.Summary.OpeningBalance,
+			)
+		}
+	}
+	return nil
+}
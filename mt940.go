@@ -0,0 +1,153 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MT940Writer converts a parsed BCAParser into a SWIFT MT940 statement, the
+// plain text format most core-banking and accounting systems ingest for
+// automated reconciliation.
+type MT940Writer struct {
+	// TransactionReference seeds the :20: field. Defaults to "STMT".
+	TransactionReference string
+	// SequenceNumber seeds the :28C: field. Defaults to "1".
+	SequenceNumber string
+}
+
+// Write renders p as an MT940 file at filename: :20:/:25:/:28C: header,
+// :60F: opening balance, one :61:/:86: pair per transaction, then :62F:
+// closing balance and :64: available balance.
+func (w *MT940Writer) Write(p *BCAParser, filename string) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	ref := w.TransactionReference
+	if ref == "" {
+		ref = "STMT"
+	}
+	seq := w.SequenceNumber
+	if seq == "" {
+		seq = "1"
+	}
+	currency := p.AccountInfo.Currency
+	if currency == "" {
+		currency = "IDR"
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, ":20:%s\n", ref)
+	fmt.Fprintf(&sb, ":25:%s\n", p.AccountInfo.AccountNumber)
+	fmt.Fprintf(&sb, ":28C:%s\n", seq)
+	fmt.Fprintf(&sb, ":60F:%s\n", mt940Balance(p.Summary.OpeningBalance, mt940OpeningDate(p), currency))
+
+	for _, txn := range p.Transactions {
+		if txn.Type != "CR" && txn.Type != "DB" {
+			continue // SALDO AWAL is already covered by :60F:
+		}
+		fmt.Fprintf(&sb, ":61:%s\n", mt940TransactionLine(txn))
+		if info := mt940WrapInfo(txn.Description); info != "" {
+			fmt.Fprintf(&sb, ":86:%s\n", info)
+		}
+	}
+
+	fmt.Fprintf(&sb, ":62F:%s\n", mt940Balance(p.Summary.ClosingBalance, mt940ClosingDate(p), currency))
+	fmt.Fprintf(&sb, ":64:%s\n", mt940Balance(p.Summary.ClosingBalance, mt940ClosingDate(p), currency))
+
+	_, err = f.WriteString(sb.String())
+	return err
+}
+
+// mt940OpeningDate and mt940ClosingDate anchor the :60F:/:62F: balance
+// fields to the first and last parsed transaction dates.
+func mt940OpeningDate(p *BCAParser) time.Time {
+	if len(p.Transactions) > 0 {
+		return p.Transactions[0].Date
+	}
+	return time.Time{}
+}
+
+func mt940ClosingDate(p *BCAParser) time.Time {
+	if len(p.Transactions) > 0 {
+		return p.Transactions[len(p.Transactions)-1].Date
+	}
+	return time.Time{}
+}
+
+// mt940Balance formats a :60F:/:62F:/:64: style field: a D/C mark, the date
+// as YYMMDD, the ISO currency code, and the amount in MT940 decimal.
+func mt940Balance(amount float64, date time.Time, currency string) string {
+	mark := "C"
+	if amount < 0 {
+		mark = "D"
+	}
+	return fmt.Sprintf("%s%s%s%s", mark, date.Format("060102"), currency, mt940Amount(math.Abs(amount)))
+}
+
+// mt940TransactionLine formats the body of a :61: tag: value date, entry
+// date, D/C mark, amount, a heuristic transaction-type code, and a
+// reference. BCA TXT exports carry no bank-assigned reference we can
+// recover, so NONREF is used as a placeholder, matching the convention
+// other MT940 generators use when the source has none.
+func mt940TransactionLine(txn Transaction) string {
+	mark := "C"
+	if txn.Type == "DB" {
+		mark = "D"
+	}
+	valueDate := txn.Date.Format("060102")
+	entryDate := txn.Date.Format("0102")
+	return fmt.Sprintf("%s%s%s%s%sNONREF", valueDate, entryDate, mark, mt940Amount(txn.Amount), mt940TransactionCode(txn))
+}
+
+// mt940TransactionCode heuristically maps a transaction's description (and,
+// for BI-FAST, its direction) to the :61: transaction-type/reference code
+// BCA statements most commonly imply: NTRF for transfers, NMSC for card
+// payments, NCHG for fees, NINT for interest, NDDT for BI-FAST debits.
+func mt940TransactionCode(txn Transaction) string {
+	upper := strings.ToUpper(txn.Description)
+	switch {
+	case strings.Contains(upper, "TRSF"):
+		return "NTRF"
+	case strings.Contains(upper, "BIAYA ADM"):
+		return "NCHG"
+	case strings.Contains(upper, "BUNGA"):
+		return "NINT"
+	case strings.Contains(upper, "BI-FAST") && txn.Type == "DB":
+		return "NDDT"
+	case strings.Contains(upper, "KARTU DEBIT"):
+		return "NMSC"
+	default:
+		return "NMSC"
+	}
+}
+
+// mt940WrapInfo wraps text into 65-character lines per the MT940 :86: spec,
+// joined with newlines so continuation lines land on their own row.
+func mt940WrapInfo(text string) string {
+	const width = 65
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return ""
+	}
+	var lines []string
+	for len(text) > width {
+		lines = append(lines, text[:width])
+		text = text[width:]
+	}
+	lines = append(lines, text)
+	return strings.Join(lines, "\n")
+}
+
+// mt940Amount renders an amount with a comma decimal separator and no
+// thousands separator, as MT940 requires.
+func mt940Amount(amount float64) string {
+	s := strconv.FormatFloat(amount, 'f', 2, 64)
+	return strings.Replace(s, ".", ",", 1)
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// AmountRange is an inclusive [Min, Max] bound used by Rule.Amount. Leaving
+// Min or Max at zero leaves that side of the range unconstrained, so a rule
+// that only cares about a floor or a ceiling doesn't need to guess a sentinel.
+type AmountRange struct {
+	Min float64 `json:"min"`
+	Max float64 `json:"max"`
+}
+
+// Rule matches a Transaction on any combination of a regex against
+// Description, an exact Type, an amount range, and a day-of-month, and on
+// match can set Category, Subcategory, Counterparty, and Tags, and/or
+// rewrite Description via a regexp capture-group template such as
+// "${1} — ${2}". This is the equivalent of hledger's CSV `if`-blocks: a
+// user adding a new merchant pattern edits the rules file, not this binary.
+type Rule struct {
+	Match      string       `json:"match"` // regex tested against Description; empty matches any description
+	Type       string       `json:"type"`  // exact Transaction.Type match; empty matches any type
+	Amount     *AmountRange `json:"amount,omitempty"`
+	DayOfMonth int          `json:"day_of_month,omitempty"` // 1-31; 0 means unconstrained
+
+	Category     string   `json:"category,omitempty"`
+	Subcategory  string   `json:"subcategory,omitempty"`
+	Counterparty string   `json:"counterparty,omitempty"`
+	Tags         []string `json:"tags,omitempty"`
+	RewriteTo    string   `json:"rewrite_to,omitempty"` // Description template, e.g. "${1} — ${2}"
+
+	compiled *regexp.Regexp
+}
+
+// RuleSet is an ordered list of Rules applied to every Transaction in a
+// BCAParser by ApplyRules. Rules are tried in file order; for each settable
+// field the first rule that matches and sets that field wins, so a narrow
+// rule near the top of the file can override a catch-all further down.
+type RuleSet struct {
+	Rules []Rule `json:"rules"`
+
+	// Explain holds, after ApplyRules runs, the index into Rules of the
+	// first rule that matched each transaction (by position in the
+	// BCAParser's Transactions slice), or -1 if none did. Populated for
+	// the CLI's -explain flag.
+	Explain []int `json:"-"`
+}
+
+// LoadRuleSet reads and compiles a RuleSet from a JSON rules file.
+func LoadRuleSet(filename string) (*RuleSet, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("LoadRuleSet: %w", err)
+	}
+	var rs RuleSet
+	if err := json.Unmarshal(data, &rs); err != nil {
+		return nil, fmt.Errorf("LoadRuleSet: %s: %w", filename, err)
+	}
+	for i := range rs.Rules {
+		rule := &rs.Rules[i]
+		if rule.Match == "" {
+			continue
+		}
+		re, err := regexp.Compile(rule.Match)
+		if err != nil {
+			return nil, fmt.Errorf("LoadRuleSet: %s: rule %d: %w", filename, i, err)
+		}
+		rule.compiled = re
+	}
+	return &rs, nil
+}
+
+// matches reports whether rule applies to txn, returning the regex
+// submatches (for RewriteTo expansion) when rule.Match is set.
+func (rule Rule) matches(txn Transaction) (ok bool, submatches []string) {
+	if rule.Type != "" && rule.Type != txn.Type {
+		return false, nil
+	}
+	if rule.Amount != nil {
+		if rule.Amount.Min != 0 && txn.Amount < rule.Amount.Min {
+			return false, nil
+		}
+		if rule.Amount.Max != 0 && txn.Amount > rule.Amount.Max {
+			return false, nil
+		}
+	}
+	if rule.DayOfMonth != 0 && txn.Date.Day() != rule.DayOfMonth {
+		return false, nil
+	}
+	if rule.compiled == nil {
+		return true, nil
+	}
+	submatches = rule.compiled.FindStringSubmatch(txn.Description)
+	return submatches != nil, submatches
+}
+
+// Apply runs every rule in rs against txn in order and returns the rewritten
+// transaction plus the index of the first rule that matched (-1 if none
+// did). Each of Category, Subcategory, Counterparty, and Description is set
+// at most once, by the first matching rule that specifies it; Tags
+// accumulate from every matching rule instead of first-match-wins, since
+// tags are additive by nature.
+func (rs *RuleSet) Apply(txn Transaction) (result Transaction, firedIndex int) {
+	result = txn
+	firedIndex = -1
+
+	var gotCategory, gotSubcategory, gotCounterparty, gotRewrite bool
+	for i, rule := range rs.Rules {
+		ok, submatches := rule.matches(txn)
+		if !ok {
+			continue
+		}
+		if firedIndex == -1 {
+			firedIndex = i
+		}
+		if !gotCategory && rule.Category != "" {
+			result.Category = rule.Category
+			gotCategory = true
+		}
+		if !gotSubcategory && rule.Subcategory != "" {
+			result.Subcategory = rule.Subcategory
+			gotSubcategory = true
+		}
+		if !gotCounterparty && rule.Counterparty != "" {
+			result.Counterparty = rule.Counterparty
+			gotCounterparty = true
+		}
+		if len(rule.Tags) > 0 {
+			result.Tags = append(result.Tags, rule.Tags...)
+		}
+		if !gotRewrite && rule.RewriteTo != "" {
+			result.Description = expandRewriteTemplate(rule.RewriteTo, submatches)
+			gotRewrite = true
+		}
+	}
+	return result, firedIndex
+}
+
+// expandRewriteTemplate substitutes "${1}", "${2}", etc. in template with
+// the corresponding regexp capture groups from submatches (submatches[0] is
+// the whole match, submatches[n] is capture group n).
+func expandRewriteTemplate(template string, submatches []string) string {
+	result := template
+	for i := len(submatches) - 1; i >= 1; i-- {
+		result = strings.ReplaceAll(result, fmt.Sprintf("${%d}", i), submatches[i])
+	}
+	return result
+}
+
+// ApplyRules runs ruleSet against every transaction in p.Transactions in
+// place, and records which rule fired for each in ruleSet.Explain. Call
+// after Parse (or after MergeParsers) so rules see the Category the
+// default Categorizer already assigned and can override it.
+func (p *BCAParser) ApplyRules(ruleSet *RuleSet) {
+	ruleSet.Explain = make([]int, len(p.Transactions))
+	for i, txn := range p.Transactions {
+		rewritten, fired := ruleSet.Apply(txn)
+		p.Transactions[i] = rewritten
+		ruleSet.Explain[i] = fired
+	}
+}
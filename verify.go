@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+// balanceEpsilon is the tolerance for float rounding noise when comparing
+// running balances; BCA statements are denominated in whole-cent IDR, so
+// anything larger indicates a real discrepancy rather than rounding.
+const balanceEpsilon = 0.01
+
+// Discrepancy describes one place Verify found the parsed statement
+// internally inconsistent. Index is -1 for summary-level checks that don't
+// anchor to a single transaction.
+type Discrepancy struct {
+	Index          int
+	Expected       float64
+	Actual         float64
+	SuggestedCause string
+}
+
+// String renders a Discrepancy as a single human-readable line, suitable
+// for PrintSummary's status output.
+func (d Discrepancy) String() string {
+	if d.Index < 0 {
+		return fmt.Sprintf("summary: expected %.2f, got %.2f (%s)", d.Expected, d.Actual, d.SuggestedCause)
+	}
+	return fmt.Sprintf("transaction #%d: expected balance %.2f, got %.2f (%s)", d.Index, d.Expected, d.Actual, d.SuggestedCause)
+}
+
+// Verify walks Transactions in order and checks that each running balance
+// reconciles with the previous one, then checks Summary's totals and
+// counts against what was actually parsed. Given how many PDF-format edge
+// cases are already documented in this parser (see spbuPumpCode,
+// embeddedTxnDate, isEchoLine), Verify turns a silent parser regression
+// into a loud, itemized failure instead of a wrong spreadsheet nobody
+// notices.
+func (p *BCAParser) Verify() []Discrepancy {
+	var discrepancies []Discrepancy
+
+	var prevBalance float64
+	haveBalance := false
+	var creditCount, debitCount int
+
+	for i, txn := range p.Transactions {
+		if txn.Type == "OPENING" {
+			prevBalance = txn.Balance
+			haveBalance = true
+			continue
+		}
+		if txn.Type == "CR" {
+			creditCount++
+		} else if txn.Type == "DB" {
+			debitCount++
+		}
+
+		if !haveBalance {
+			// No SALDO AWAL row seen yet (e.g. a standalone month slice) —
+			// seed the running balance from this transaction instead of
+			// flagging a spurious mismatch against a zero baseline.
+			prevBalance = txn.Balance
+			haveBalance = true
+			continue
+		}
+
+		expected := prevBalance + signedAmount(txn)
+		if math.Abs(expected-txn.Balance) > balanceEpsilon {
+			discrepancies = append(discrepancies, Discrepancy{
+				Index:          i,
+				Expected:       expected,
+				Actual:         txn.Balance,
+				SuggestedCause: discrepancyCause(txn),
+			})
+		}
+		prevBalance = txn.Balance
+	}
+
+	expectedClosing := p.Summary.OpeningBalance + p.Summary.TotalCredits - p.Summary.TotalDebits
+	if math.Abs(expectedClosing-p.Summary.ClosingBalance) > balanceEpsilon {
+		discrepancies = append(discrepancies, Discrepancy{
+			Index:          -1,
+			Expected:       expectedClosing,
+			Actual:         p.Summary.ClosingBalance,
+			SuggestedCause: "opening + credits - debits does not match the statement's closing balance",
+		})
+	}
+
+	if creditCount != p.Summary.CreditCount {
+		discrepancies = append(discrepancies, Discrepancy{
+			Index:          -1,
+			Expected:       float64(p.Summary.CreditCount),
+			Actual:         float64(creditCount),
+			SuggestedCause: "parsed credit count does not match the statement's MUTASI CR count",
+		})
+	}
+	if debitCount != p.Summary.DebitCount {
+		discrepancies = append(discrepancies, Discrepancy{
+			Index:          -1,
+			Expected:       float64(p.Summary.DebitCount),
+			Actual:         float64(debitCount),
+			SuggestedCause: "parsed debit count does not match the statement's MUTASI DB count",
+		})
+	}
+
+	return discrepancies
+}
+
+// signedAmount returns txn.Amount for a credit and -txn.Amount for a debit.
+func signedAmount(txn Transaction) float64 {
+	if txn.Type == "DB" {
+		return -txn.Amount
+	}
+	return txn.Amount
+}
+
+// discrepancyCause guesses why a transaction's running balance doesn't
+// reconcile, based on the known PDF-format edge cases this parser already
+// works around.
+func discrepancyCause(txn Transaction) string {
+	switch {
+	case txn.Type == "":
+		return "missing CR/DB classification — possibly a misclassified transaction"
+	case txn.Description == "":
+		return "empty description — possibly a dropped continuation line"
+	default:
+		return "possible embedded-date split failure or dropped echo line"
+	}
+}